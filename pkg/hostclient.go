@@ -0,0 +1,53 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// HostClientOptions tunes a HostClient. Zero-valued fields fall back to
+// fasthttp.HostClient's own defaults.
+type HostClientOptions struct {
+	Addr                string
+	IsTLS               bool
+	MaxConns            int
+	MaxIdleConnDuration time.Duration
+	ReadTimeout         time.Duration
+	WriteTimeout        time.Duration
+	DialDualStack       bool
+	TLSConfig           *tls.Config
+}
+
+// HostClient executes requests against a single host through a dedicated
+// fasthttp.HostClient. Prefer registering one via Client.WithHostOverride
+// for a single heavily-loaded endpoint instead of sharing Client's
+// fasthttp.Client across every host.
+type HostClient struct {
+	client  *fasthttp.HostClient
+	timeout time.Duration
+}
+
+// NewHostClient builds a HostClient from opts, using timeout as the
+// per-request deadline passed to DoTimeout.
+func NewHostClient(opts *HostClientOptions, timeout time.Duration) *HostClient {
+	return &HostClient{
+		client: &fasthttp.HostClient{
+			Addr:                opts.Addr,
+			IsTLS:               opts.IsTLS,
+			MaxConns:            opts.MaxConns,
+			MaxIdleConnDuration: opts.MaxIdleConnDuration,
+			ReadTimeout:         opts.ReadTimeout,
+			WriteTimeout:        opts.WriteTimeout,
+			DialDualStack:       opts.DialDualStack,
+			TLSConfig:           opts.TLSConfig,
+		},
+		timeout: timeout,
+	}
+}
+
+func (hc *HostClient) Do(ctx context.Context, req *Request) (*Response, error) {
+	return doFastHTTPCtx(ctx, hc.client, hc.timeout, req)
+}
@@ -3,11 +3,11 @@ package http
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
-
-	"github.com/valyala/fasthttp"
 )
 
 type HTTPMethod string
@@ -18,6 +18,7 @@ const (
 	PUT    HTTPMethod = "PUT"
 	PATH   HTTPMethod = "POST"
 	DELETE HTTPMethod = "DELETE"
+	HEAD   HTTPMethod = "HEAD"
 )
 
 type Request struct {
@@ -27,6 +28,20 @@ type Request struct {
 	Headers     map[string]string
 	Query       map[string]string
 	Body        string
+	// BodyReader, when set, is sent instead of Body by both Do and DoStream
+	// (it takes priority over Body). BodyLength is the number of bytes it will
+	// yield; any value <= 0, including the zero value, means unknown and
+	// streams until io.EOF instead of sending Content-Length: 0. Note that
+	// fasthttp.Request.SetBodyStream takes an int, so a BodyLength above
+	// math.MaxInt32 truncates on 32-bit builds.
+	BodyReader io.Reader
+	BodyLength int64
+	// GetBody, when set, returns a fresh BodyReader for each retry attempt,
+	// mirroring net/http.Request.GetBody. Without it, a retry can only
+	// replay a BodyReader that also implements io.Seeker (rewound to the
+	// start before each attempt); any other BodyReader is treated as
+	// unsafe to retry and the request is sent at most once.
+	GetBody func() io.Reader
 }
 
 type Response struct {
@@ -34,13 +49,20 @@ type Response struct {
 	Headers    map[string]string
 	StatusCode int32
 	Time       int64
+	// BodyStream is only populated by DoStream. The caller must Close() it
+	// once done reading, or the pooled connection/response will leak.
+	BodyStream io.ReadCloser
 }
 
 type Client struct {
-	Client      *fasthttp.Client
-	baseURL     string
-	contentType string
-	timeout     time.Duration
+	transport     Transport
+	baseURL       string
+	contentType   string
+	timeout       time.Duration
+	retryPolicy   *RetryPolicy
+	hostOverrides map[string]*HostClient
+	hostMu        sync.RWMutex
+	handler       Handler
 }
 
 type NewClientOptions struct {
@@ -49,6 +71,16 @@ type NewClientOptions struct {
 	Timeout            time.Duration
 	Attemps            int
 	TLSCert            string
+	// Transport is the driver used to execute requests. When nil, it
+	// defaults to FastHTTPTransport to preserve existing behavior.
+	Transport Transport
+	// RetryPolicy governs retries on top of Transport.Do. When nil, it
+	// defaults to DefaultRetryPolicy().
+	RetryPolicy *RetryPolicy
+	// Middlewares wrap the request in registration order: the first one
+	// is outermost. Do's own dispatch/retry logic is the terminal Handler
+	// they all wrap.
+	Middlewares []Middleware
 }
 
 type DoOptions struct {
@@ -67,66 +99,155 @@ func New(opts ...*NewClientOptions) *Client {
 		opt = opts[0]
 	}
 
+	transport := opt.Transport
+	if transport == nil {
+		transport = NewFastHTTPTransport(opt.Timeout, opt.Attemps)
+	}
+
+	retryPolicy := opt.RetryPolicy
+	if retryPolicy == nil {
+		retryPolicy = DefaultRetryPolicy()
+	}
+
 	c := &Client{
-		Client:      &fasthttp.Client{},
+		transport:   transport,
 		baseURL:     opt.BaseURL,
 		contentType: opt.DefaultContentType,
 		timeout:     opt.Timeout,
+		retryPolicy: retryPolicy,
 	}
 
-	c.Client.MaxIdemponentCallAttempts = opt.Attemps
+	c.handler = chain(opt.Middlewares, c.terminalHandler)
 
 	return c
 }
 
+// Do resolves opts.Request against the Client's base URL/content type,
+// bounds it by the configured timeout, and runs it through the
+// middleware chain built at New() time.
 func (h *Client) Do(ctx context.Context, opts *DoOptions) (*Response, error) {
 	if opts.StartTime == nil {
 		n := time.Now()
 		opts.StartTime = &n
 	}
 
-	req := fasthttp.AcquireRequest()
-	res := fasthttp.AcquireResponse()
-	defer fasthttp.ReleaseRequest(req)
-	defer fasthttp.ReleaseResponse(res)
+	resolved := *opts.Request
+	resolved.URL = h.resolveURL(opts.Request)
+	resolved.Headers = cloneHeaders(opts.Request.Headers)
 
-	u := h.getURL(opts.Request.URL)
+	if resolved.ContentType == "" {
+		resolved.ContentType = h.contentType
+	}
 
-	qp := url.Values{}
-	for k, v := range opts.Request.Query {
-		qp.Add(k, fmt.Sprint(v))
+	if h.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.timeout)
+		defer cancel()
 	}
 
-	if len(qp) > 0 {
-		u = fmt.Sprintf("%s?%s", u, qp.Encode())
+	res, err := h.handler(ctx, &resolved)
+	if err != nil {
+		return nil, err
 	}
 
-	cType := h.contentType
-	if opts.Request.ContentType != "" {
-		cType = opts.Request.ContentType
+	endNow := time.Now()
+	res.Time = getResponseTime(opts.StartTime, &endNow)
+
+	return res, nil
+}
+
+// terminalHandler is the innermost Handler in the middleware chain: it
+// picks the right Transport for the request's host and drives the retry
+// loop. ctx carries the overall Do timeout, so sleepCtx and the
+// Transport's own ctx handling are what actually bound attempt latency.
+func (h *Client) terminalHandler(ctx context.Context, req *Request) (*Response, error) {
+	transport := h.transportFor(req.URL)
+	maxAttempts := h.retryPolicy.maxAttempts()
+
+	if !bodyReplayable(req) {
+		maxAttempts = 1
 	}
 
-	req.SetRequestURI(u)
-	req.Header.SetMethod(string(opts.Request.Method))
-	req.Header.SetContentType(cType)
-	req.SetBodyString(opts.Request.Body)
+	var (
+		res *Response
+		err error
+	)
 
-	for k, v := range opts.Request.Headers {
-		req.Header.Set(k, v)
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if attempt > 0 {
+			if sErr := sleepCtx(ctx, h.retryPolicy.backoff(attempt, res)); sErr != nil {
+				return nil, sErr
+			}
+
+			if rErr := rewindBody(req); rErr != nil {
+				return nil, rErr
+			}
+		}
+
+		res, err = transport.Do(ctx, req)
+		if !h.retryPolicy.shouldRetry(req, res, err) {
+			break
+		}
 	}
 
-	if err := h.Client.DoTimeout(req, res, h.timeout); err != nil {
+	if err != nil {
 		return nil, err
 	}
 
-	endNow := time.Now()
+	return res, nil
+}
+
+// Route returns the HostClient registered for host, or nil if no override
+// was registered via WithHostOverride.
+func (h *Client) Route(host string) *HostClient {
+	h.hostMu.RLock()
+	defer h.hostMu.RUnlock()
+
+	return h.hostOverrides[host]
+}
+
+// WithHostOverride registers a dedicated HostClient for host, so that Do
+// dispatches requests to it instead of the shared Transport. Use this for
+// a single heavily-loaded endpoint that needs its own connection/timeout
+// tuning.
+func (h *Client) WithHostOverride(host string, opts *HostClientOptions) *Client {
+	h.hostMu.Lock()
+	defer h.hostMu.Unlock()
+
+	if h.hostOverrides == nil {
+		h.hostOverrides = map[string]*HostClient{}
+	}
+
+	h.hostOverrides[host] = NewHostClient(opts, h.timeout)
+
+	return h
+}
+
+// transportFor picks the HostClient registered for rawURL's host, falling
+// back to the shared Transport when none was registered.
+func (h *Client) transportFor(rawURL string) Transport {
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		if hc := h.Route(u.Host); hc != nil {
+			return hc
+		}
+	}
+
+	return h.transport
+}
+
+func (h *Client) resolveURL(r *Request) string {
+	u := h.getURL(r.URL)
+
+	qp := url.Values{}
+	for k, v := range r.Query {
+		qp.Add(k, fmt.Sprint(v))
+	}
+
+	if len(qp) > 0 {
+		u = fmt.Sprintf("%s?%s", u, qp.Encode())
+	}
 
-	return &Response{
-		StatusCode: int32(res.StatusCode()),
-		Body:       string(res.Body()),
-		Headers:    mergeResponseHeaders(&res.Header),
-		Time:       getResponseTime(opts.StartTime, &endNow),
-	}, nil
+	return u
 }
 
 func (h *Client) getURL(rURL string) string {
@@ -137,16 +258,63 @@ func (h *Client) getURL(rURL string) string {
 	return h.baseURL + rURL
 }
 
-func mergeResponseHeaders(h *fasthttp.ResponseHeader) map[string]string {
-	headers := map[string]string{}
+func getResponseTime(start, end *time.Time) int64 {
+	return end.Sub(*start).Milliseconds()
+}
+
+// bodyReplayable reports whether req's body can be safely resent on a
+// retry. A plain Body string can always be resent; a BodyReader can only
+// be resent if GetBody can recreate it or the reader can seek back to the
+// start.
+func bodyReplayable(req *Request) bool {
+	if req.BodyReader == nil {
+		return true
+	}
+
+	if req.GetBody != nil {
+		return true
+	}
+
+	_, ok := req.BodyReader.(io.Seeker)
+
+	return ok
+}
+
+// rewindBody prepares req.BodyReader for another attempt, recreating it
+// via GetBody or seeking it back to the start. bodyReplayable must have
+// already confirmed one of those is possible.
+func rewindBody(req *Request) error {
+	if req.BodyReader == nil {
+		return nil
+	}
+
+	if req.GetBody != nil {
+		req.BodyReader = req.GetBody()
+		return nil
+	}
+
+	seeker, ok := req.BodyReader.(io.Seeker)
+	if !ok {
+		return nil
+	}
 
-	h.VisitAll(func(key, value []byte) {
-		headers[string(key)] = string(value)
-	})
+	_, err := seeker.Seek(0, io.SeekStart)
 
-	return headers
+	return err
 }
 
-func getResponseTime(start, end *time.Time) int64 {
-	return end.Sub(*start).Milliseconds()
+// cloneHeaders copies headers so that middleware mutating the resolved
+// Request (e.g. RequestIDMiddleware, SPNEGOMiddleware) never writes back
+// into the caller's original Request.
+func cloneHeaders(headers map[string]string) map[string]string {
+	if headers == nil {
+		return nil
+	}
+
+	cloned := make(map[string]string, len(headers))
+	for k, v := range headers {
+		cloned[k] = v
+	}
+
+	return cloned
 }
@@ -0,0 +1,177 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Codec marshals/unmarshals the bodies used by DoJSON and its siblings.
+// The default is JSONCodec; swap in an easyjson/sonic/jsoniter-backed
+// implementation via TypedRequest.Codec to skip reflection on hot paths.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+	ContentType() string
+}
+
+// JSONCodec is the default Codec, backed by encoding/json.
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (JSONCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+func (JSONCodec) ContentType() string { return "application/json" }
+
+// TypedRequest is the input to DoJSON and its Get/Post/Put/Patch/Delete
+// shorthands.
+type TypedRequest[Req any] struct {
+	URL     string
+	Method  HTTPMethod
+	Headers map[string]string
+	Query   map[string]string
+	Body    Req
+	// ExpectedStatus is the whitelist of status codes treated as success.
+	// Empty means any 2xx.
+	ExpectedStatus []int
+	// ErrorBody, when set, receives the decoded error body when the
+	// response fails ExpectedStatus; see APIError.Decoded.
+	ErrorBody any
+	// Codec defaults to JSONCodec when nil.
+	Codec Codec
+}
+
+// TypedResponse is the output of DoJSON and its shorthands.
+type TypedResponse[Res any] struct {
+	Body       Res
+	Headers    map[string]string
+	StatusCode int32
+	Time       int64
+}
+
+// APIError wraps a response outside TypedRequest.ExpectedStatus so callers
+// can errors.As for the decoded error body instead of string-matching.
+type APIError struct {
+	StatusCode int32
+	Body       []byte
+	// Decoded is the value passed as TypedRequest.ErrorBody, populated if
+	// decoding succeeded; nil otherwise.
+	Decoded any
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("simple_httpclient: unexpected status code %d", e.StatusCode)
+}
+
+// DoJSON marshals req.Body (skipped for GET/DELETE), runs it through
+// Client.Do, checks the status against req.ExpectedStatus and unmarshals
+// the response into Res.
+func DoJSON[Req, Res any](ctx context.Context, c *Client, req *TypedRequest[Req]) (*TypedResponse[Res], error) {
+	codec := req.Codec
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+
+	var body string
+	if req.Method != GET && req.Method != DELETE {
+		b, err := codec.Marshal(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = string(b)
+	}
+
+	res, err := c.Do(ctx, &DoOptions{
+		Request: &Request{
+			URL:         req.URL,
+			Method:      req.Method,
+			ContentType: codec.ContentType(),
+			Headers:     req.Headers,
+			Query:       req.Query,
+			Body:        body,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !statusExpected(res.StatusCode, req.ExpectedStatus) {
+		return nil, newAPIError(res, req.ErrorBody, codec)
+	}
+
+	var decoded Res
+	if len(res.Body) > 0 {
+		if err := codec.Unmarshal([]byte(res.Body), &decoded); err != nil {
+			return nil, err
+		}
+	}
+
+	return &TypedResponse[Res]{
+		Body:       decoded,
+		Headers:    res.Headers,
+		StatusCode: res.StatusCode,
+		Time:       res.Time,
+	}, nil
+}
+
+func statusExpected(status int32, expected []int) bool {
+	if len(expected) == 0 {
+		return status >= 200 && status < 300
+	}
+
+	for _, s := range expected {
+		if int32(s) == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newAPIError(res *Response, errorBody any, codec Codec) *APIError {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		Body:       []byte(res.Body),
+	}
+
+	if errorBody != nil && len(res.Body) > 0 {
+		if err := codec.Unmarshal([]byte(res.Body), errorBody); err == nil {
+			apiErr.Decoded = errorBody
+		}
+	}
+
+	return apiErr
+}
+
+// Get is a DoJSON shorthand for requests with no request body.
+func Get[Res any](ctx context.Context, c *Client, req *TypedRequest[any]) (*TypedResponse[Res], error) {
+	req.Method = GET
+	return DoJSON[any, Res](ctx, c, req)
+}
+
+// Post is a DoJSON shorthand for POST requests.
+func Post[Req, Res any](ctx context.Context, c *Client, req *TypedRequest[Req]) (*TypedResponse[Res], error) {
+	req.Method = POST
+	return DoJSON[Req, Res](ctx, c, req)
+}
+
+// Put is a DoJSON shorthand for PUT requests.
+func Put[Req, Res any](ctx context.Context, c *Client, req *TypedRequest[Req]) (*TypedResponse[Res], error) {
+	req.Method = PUT
+	return DoJSON[Req, Res](ctx, c, req)
+}
+
+// Patch is a DoJSON shorthand for PATCH requests. It does not reuse the
+// HTTPMethod.PATH constant, which is pinned to "POST" for backwards
+// compatibility with existing callers of that (mislabeled) constant.
+func Patch[Req, Res any](ctx context.Context, c *Client, req *TypedRequest[Req]) (*TypedResponse[Res], error) {
+	req.Method = HTTPMethod("PATCH")
+	return DoJSON[Req, Res](ctx, c, req)
+}
+
+// Delete is a DoJSON shorthand for requests with no request body.
+func Delete[Res any](ctx context.Context, c *Client, req *TypedRequest[any]) (*TypedResponse[Res], error) {
+	req.Method = DELETE
+	return DoJSON[any, Res](ctx, c, req)
+}
@@ -0,0 +1,23 @@
+package http
+
+import "context"
+
+// Handler executes a single resolved request. Client.Do's own
+// dispatch/retry logic is the terminal Handler every Middleware wraps.
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps a Handler with cross-cutting behavior: logging,
+// tracing, metrics, auth, request ID propagation, and so on. Register
+// middlewares via NewClientOptions.Middlewares; the first one registered
+// is outermost.
+type Middleware func(next Handler) Handler
+
+func chain(mws []Middleware, terminal Handler) Handler {
+	h := terminal
+
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+
+	return h
+}
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Logger has the key/value call shape of *slog.Logger, which satisfies it
+// directly; loggers whose Info/Error take only variadic args (zap's
+// SugaredLogger, logrus) need a thin adapter.
+type Logger interface {
+	Info(msg string, keyvals ...any)
+	Error(msg string, keyvals ...any)
+}
+
+// LoggingMiddleware logs one line per request with method, URL, status
+// (or error) and elapsed time.
+func LoggingMiddleware(logger Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			start := time.Now()
+			res, err := next(ctx, req)
+			elapsed := time.Since(start)
+
+			if err != nil {
+				logger.Error("http request failed",
+					"method", req.Method, "url", req.URL, "elapsed", elapsed, "error", err)
+				return nil, err
+			}
+
+			logger.Info("http request",
+				"method", req.Method, "url", req.URL, "elapsed", elapsed, "status", res.StatusCode)
+
+			return res, nil
+		}
+	}
+}
+
+type requestIDContextKey struct{}
+
+// WithRequestID attaches a request ID to ctx for RequestIDMiddleware to
+// propagate on the outbound request.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestIDMiddleware sets header on the outbound request to the request
+// ID attached to ctx via WithRequestID, generating one with gen if absent.
+// header defaults to "X-Request-ID".
+func RequestIDMiddleware(header string, gen func() string) Middleware {
+	if header == "" {
+		header = "X-Request-ID"
+	}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			id, _ := ctx.Value(requestIDContextKey{}).(string)
+			if id == "" && gen != nil {
+				id = gen()
+			}
+
+			if id != "" {
+				setHeader(req, header, id)
+			}
+
+			return next(ctx, req)
+		}
+	}
+}
+
+// Tracer is a minimal span interface, satisfied by a thin adapter over
+// go.opentelemetry.io/otel's Tracer. TracingMiddleware stays decoupled
+// from the otel SDK itself, the way Codec stays decoupled from any one
+// JSON library.
+type Tracer interface {
+	// Start begins a span for req and returns the context carrying it,
+	// the traceparent header value to propagate, and a func to call with
+	// the outcome once the request completes.
+	Start(ctx context.Context, req *Request) (context.Context, string, func(res *Response, err error))
+}
+
+// TracingMiddleware starts a span per request via tracer, injecting the
+// resulting traceparent header on the outbound request.
+func TracingMiddleware(tracer Tracer) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			ctx, traceparent, end := tracer.Start(ctx, req)
+			if traceparent != "" {
+				setHeader(req, "traceparent", traceparent)
+			}
+
+			res, err := next(ctx, req)
+			end(res, err)
+
+			return res, err
+		}
+	}
+}
+
+// MetricsRecorder is satisfied by a thin adapter over Prometheus
+// histograms/counters (or any other metrics backend). Since middlewares
+// wrap terminalHandler (which contains the whole retry loop), ObserveRequest
+// is called once per Do call, not once per attempt: elapsed includes every
+// retried attempt and the backoff sleeps between them.
+type MetricsRecorder interface {
+	ObserveRequest(method HTTPMethod, host string, status int32, elapsed time.Duration, err error)
+}
+
+// MetricsMiddleware reports method/host/status/elapsed/err to recorder
+// once per Do call (see MetricsRecorder).
+func MetricsMiddleware(recorder MetricsRecorder) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			start := time.Now()
+			res, err := next(ctx, req)
+
+			var status int32
+			if res != nil {
+				status = res.StatusCode
+			}
+
+			recorder.ObserveRequest(req.Method, requestHost(req.URL), status, time.Since(start), err)
+
+			return res, err
+		}
+	}
+}
+
+// CredentialsProvider supplies a SPNEGO/Kerberos Negotiate token for a
+// request, mirroring the way git-lfs decouples itself from a specific
+// Kerberos library (dpotapov/go-spnego) behind an interface.
+type CredentialsProvider interface {
+	NegotiateHeader(ctx context.Context, req *Request) (string, error)
+}
+
+// SPNEGOMiddleware sets the Authorization: Negotiate header from
+// provider on every outbound request for which provider returns a
+// non-empty token; an empty token leaves the request unchanged.
+func SPNEGOMiddleware(provider CredentialsProvider) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			token, err := provider.NegotiateHeader(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+
+			if token != "" {
+				setHeader(req, "Authorization", "Negotiate "+token)
+			}
+
+			return next(ctx, req)
+		}
+	}
+}
+
+func setHeader(req *Request, key, value string) {
+	if req.Headers == nil {
+		req.Headers = map[string]string{}
+	}
+
+	req.Headers[key] = value
+}
+
+func requestHost(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return u.Host
+}
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how Client.Do retries a request on top of whatever
+// connection-level retries the Transport performs on its own. It covers
+// cases fasthttp's MaxIdemponentCallAttempts cannot: retryable 5xx/429
+// responses and Retry-After handling.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries, including the first one.
+	// 1 disables retries.
+	MaxAttempts int
+	MinWait     time.Duration
+	MaxWait     time.Duration
+	// RetryableStatuses are response status codes that trigger a retry.
+	RetryableStatuses []int
+	// RetryableMethods are request methods eligible for a retry. POST is
+	// intentionally left out by default since it is rarely idempotent;
+	// callers that know otherwise can opt in.
+	RetryableMethods []HTTPMethod
+	// CheckRetry, when set, overrides RetryableStatuses/RetryableMethods
+	// entirely and decides whether a given attempt should be retried.
+	CheckRetry func(res *Response, err error) bool
+}
+
+// DefaultRetryPolicy returns the policy used when NewClientOptions.RetryPolicy
+// is left nil.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinWait:     1 * time.Second,
+		MaxWait:     30 * time.Second,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableMethods: []HTTPMethod{GET, HEAD, PUT, DELETE},
+	}
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts <= 0 {
+		return 1
+	}
+
+	return p.MaxAttempts
+}
+
+func (p *RetryPolicy) shouldRetry(req *Request, res *Response, err error) bool {
+	if p.CheckRetry != nil {
+		return p.CheckRetry(res, err)
+	}
+
+	if !methodIsRetryable(req.Method, p.RetryableMethods) {
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+
+	return statusIsRetryable(int(res.StatusCode), p.RetryableStatuses)
+}
+
+// backoff computes how long to wait before the given attempt (1-indexed,
+// since attempt 0 never waits). It honors Retry-After on the previous
+// response when present, otherwise applies full-jitter exponential backoff.
+func (p *RetryPolicy) backoff(attempt int, res *Response) time.Duration {
+	if res != nil {
+		if wait, ok := retryAfter(res); ok {
+			return wait
+		}
+	}
+
+	minWait, maxWait := p.MinWait, p.MaxWait
+	if minWait <= 0 {
+		minWait = 1 * time.Second
+	}
+	if maxWait <= 0 {
+		maxWait = 30 * time.Second
+	}
+
+	ceiling := minWait << uint(attempt)
+	if ceiling <= 0 || ceiling > maxWait {
+		ceiling = maxWait
+	}
+
+	return time.Duration(rand.Int63n(int64(ceiling)))
+}
+
+func methodIsRetryable(method HTTPMethod, methods []HTTPMethod) bool {
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+func statusIsRetryable(status int, statuses []int) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// retryAfter extracts the wait duration from a Retry-After header, which
+// may be either a number of seconds or an HTTP-date.
+func retryAfter(res *Response) (time.Duration, bool) {
+	v := headerLookup(res.Headers, "Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if wait := time.Until(t); wait > 0 {
+			return wait, true
+		}
+
+		return 0, true
+	}
+
+	return 0, false
+}
+
+func headerLookup(headers map[string]string, key string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+
+	return ""
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,165 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyMaxAttempts(t *testing.T) {
+	cases := []struct {
+		name string
+		p    *RetryPolicy
+		want int
+	}{
+		{"positive", &RetryPolicy{MaxAttempts: 5}, 5},
+		{"zero", &RetryPolicy{}, 1},
+		{"negative", &RetryPolicy{MaxAttempts: -1}, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.p.maxAttempts(); got != tc.want {
+				t.Errorf("maxAttempts() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	p := &RetryPolicy{
+		RetryableStatuses: []int{http.StatusServiceUnavailable},
+		RetryableMethods:  []HTTPMethod{GET},
+	}
+
+	getReq := &Request{Method: GET}
+	postReq := &Request{Method: POST}
+
+	if p.shouldRetry(postReq, &Response{StatusCode: http.StatusServiceUnavailable}, nil) {
+		t.Error("expected no retry for a method not in RetryableMethods")
+	}
+
+	if p.shouldRetry(postReq, nil, errors.New("boom")) {
+		t.Error("expected method gate to short-circuit even on transport error")
+	}
+
+	if !p.shouldRetry(getReq, nil, errors.New("boom")) {
+		t.Error("expected retry on transport error for a retryable method")
+	}
+
+	if !p.shouldRetry(getReq, &Response{StatusCode: http.StatusServiceUnavailable}, nil) {
+		t.Error("expected retry for a retryable status")
+	}
+
+	if p.shouldRetry(getReq, &Response{StatusCode: http.StatusOK}, nil) {
+		t.Error("expected no retry for a non-retryable status")
+	}
+
+	checkRetryPolicy := &RetryPolicy{
+		RetryableMethods: []HTTPMethod{GET},
+		CheckRetry: func(res *Response, err error) bool {
+			return false
+		},
+	}
+	if checkRetryPolicy.shouldRetry(getReq, nil, errors.New("boom")) {
+		t.Error("expected CheckRetry to override the default method/status gating entirely")
+	}
+}
+
+func TestRetryPolicyBackoffBounds(t *testing.T) {
+	p := &RetryPolicy{MinWait: 1 * time.Second, MaxWait: 30 * time.Second}
+
+	for attempt := 1; attempt <= 40; attempt++ {
+		for i := 0; i < 20; i++ {
+			wait := p.backoff(attempt, nil)
+			if wait < 0 {
+				t.Fatalf("attempt %d: backoff returned negative duration %v", attempt, wait)
+			}
+			if wait > p.MaxWait {
+				t.Fatalf("attempt %d: backoff %v exceeds MaxWait %v", attempt, wait, p.MaxWait)
+			}
+		}
+	}
+}
+
+func TestRetryPolicyBackoffDefaults(t *testing.T) {
+	p := &RetryPolicy{}
+
+	wait := p.backoff(1, nil)
+	if wait < 0 || wait > 30*time.Second {
+		t.Fatalf("backoff with zero-valued MinWait/MaxWait = %v, want within [0, 30s]", wait)
+	}
+}
+
+func TestRetryPolicyBackoffHonorsRetryAfter(t *testing.T) {
+	p := &RetryPolicy{MinWait: 1 * time.Second, MaxWait: 30 * time.Second}
+	res := &Response{Headers: map[string]string{"Retry-After": "5"}}
+
+	if got := p.backoff(1, res); got != 5*time.Second {
+		t.Errorf("backoff() = %v, want 5s from Retry-After", got)
+	}
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	res := &Response{Headers: map[string]string{"Retry-After": "2"}}
+
+	wait, ok := retryAfter(res)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+	if wait != 2*time.Second {
+		t.Errorf("retryAfter() = %v, want 2s", wait)
+	}
+}
+
+func TestRetryAfterHTTPDate(t *testing.T) {
+	future := time.Now().Add(10 * time.Second).UTC()
+	res := &Response{Headers: map[string]string{"Retry-After": future.Format(http.TimeFormat)}}
+
+	wait, ok := retryAfter(res)
+	if !ok {
+		t.Fatal("expected ok")
+	}
+	if wait <= 0 || wait > 10*time.Second {
+		t.Errorf("retryAfter() = %v, want within (0, 10s]", wait)
+	}
+}
+
+func TestRetryAfterPastHTTPDate(t *testing.T) {
+	past := time.Now().Add(-10 * time.Second).UTC()
+	res := &Response{Headers: map[string]string{"Retry-After": past.Format(http.TimeFormat)}}
+
+	wait, ok := retryAfter(res)
+	if !ok {
+		t.Fatal("expected ok for a past HTTP-date")
+	}
+	if wait != 0 {
+		t.Errorf("retryAfter() = %v, want 0 for a date already in the past", wait)
+	}
+}
+
+func TestRetryAfterMissingOrInvalid(t *testing.T) {
+	if _, ok := retryAfter(&Response{}); ok {
+		t.Error("expected ok=false when Retry-After is absent")
+	}
+
+	res := &Response{Headers: map[string]string{"Retry-After": "not-a-valid-value"}}
+	if _, ok := retryAfter(res); ok {
+		t.Error("expected ok=false for an unparseable Retry-After value")
+	}
+}
+
+func TestSleepCtxRespectsCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := sleepCtx(ctx, 1*time.Second); err == nil {
+		t.Error("expected sleepCtx to return an error for an already-cancelled ctx")
+	}
+
+	if err := sleepCtx(ctx, 0); err != nil {
+		t.Errorf("sleepCtx with d<=0 should return immediately without checking ctx, got %v", err)
+	}
+}
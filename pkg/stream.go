@@ -0,0 +1,65 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrStreamingNotSupported is returned by Client.DoStream when the
+// configured Transport does not implement StreamTransport.
+var ErrStreamingNotSupported = errors.New("simple_httpclient: transport does not support streaming")
+
+// StreamTransport is implemented by Transports that can serve a request
+// without buffering the full response body in memory. FastHTTPTransport
+// implements it.
+type StreamTransport interface {
+	DoStream(ctx context.Context, req *Request) (*Response, error)
+}
+
+// DoStream behaves like Do, except the returned Response never has Body
+// populated: instead, Response.BodyStream is set and the caller must read
+// it and call Close() when done, or the underlying connection (and, for
+// FastHTTPTransport, the pooled fasthttp.Response) will leak. It does not
+// go through RetryPolicy, since a partially-read stream cannot be safely
+// retried. Unlike Do, it does not run the middleware chain and ignores
+// host overrides registered via WithHostOverride: it always uses the
+// Client's shared Transport. Like Do, ctx (bounded by the Client's
+// timeout) can abort the call while it is waiting on headers; once
+// DoStream returns, the
+// returned BodyStream is read independently of ctx.
+func (h *Client) DoStream(ctx context.Context, opts *DoOptions) (*Response, error) {
+	st, ok := h.transport.(StreamTransport)
+	if !ok {
+		return nil, ErrStreamingNotSupported
+	}
+
+	if opts.StartTime == nil {
+		n := time.Now()
+		opts.StartTime = &n
+	}
+
+	resolved := *opts.Request
+	resolved.URL = h.resolveURL(opts.Request)
+	resolved.Headers = cloneHeaders(opts.Request.Headers)
+
+	if resolved.ContentType == "" {
+		resolved.ContentType = h.contentType
+	}
+
+	if h.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.timeout)
+		defer cancel()
+	}
+
+	res, err := st.DoStream(ctx, &resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	endNow := time.Now()
+	res.Time = getResponseTime(opts.StartTime, &endNow)
+
+	return res, nil
+}
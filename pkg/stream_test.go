@@ -0,0 +1,66 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type fakeStreamTransport struct {
+	res *Response
+	err error
+}
+
+func (f *fakeStreamTransport) Do(ctx context.Context, req *Request) (*Response, error) {
+	return f.res, f.err
+}
+
+func (f *fakeStreamTransport) DoStream(ctx context.Context, req *Request) (*Response, error) {
+	return f.res, f.err
+}
+
+type nopReadCloser struct {
+	io.Reader
+	closed bool
+}
+
+func (n *nopReadCloser) Close() error {
+	n.closed = true
+	return nil
+}
+
+func TestClientDoStreamReturnsStream(t *testing.T) {
+	body := &nopReadCloser{Reader: strings.NewReader("payload")}
+	c := &Client{transport: &fakeStreamTransport{res: &Response{StatusCode: 200, BodyStream: body}}}
+
+	res, err := c.DoStream(context.Background(), &DoOptions{Request: &Request{URL: "http://example.com"}})
+	if err != nil {
+		t.Fatalf("DoStream() error = %v", err)
+	}
+	defer res.BodyStream.Close()
+
+	data, err := io.ReadAll(res.BodyStream)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if string(data) != "payload" {
+		t.Errorf("BodyStream contents = %q, want %q", data, "payload")
+	}
+}
+
+func TestClientDoStreamUnsupportedTransport(t *testing.T) {
+	c := &Client{transport: fakeTransport{}}
+
+	_, err := c.DoStream(context.Background(), &DoOptions{Request: &Request{URL: "http://example.com"}})
+	if !errors.Is(err, ErrStreamingNotSupported) {
+		t.Errorf("DoStream() error = %v, want ErrStreamingNotSupported", err)
+	}
+}
+
+type fakeTransport struct{}
+
+func (fakeTransport) Do(ctx context.Context, req *Request) (*Response, error) {
+	return nil, nil
+}
@@ -0,0 +1,298 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valyala/fasthttp"
+	"golang.org/x/net/http2"
+)
+
+// Transport is the driver behind Client.Do. A Request passed to Do has
+// already been resolved by the Client: its URL carries the base URL and
+// query string, and an empty ContentType has been replaced by the
+// Client's DefaultContentType (which is itself empty if configured so).
+// A Transport only has to execute the round trip and translate the
+// result into a Response.
+type Transport interface {
+	Do(ctx context.Context, req *Request) (*Response, error)
+}
+
+// FastHTTPTransport is the default Transport, backed by fasthttp.Client.
+// It is the fastest option for plain HTTP/1.1 traffic but does not speak
+// HTTP/2 and has no support for http.RoundTripper-based instrumentation.
+type FastHTTPTransport struct {
+	Client  *fasthttp.Client
+	Timeout time.Duration
+
+	// streamClient is a dedicated fasthttp.Client for DoStream, with
+	// StreamResponseBody set once at construction. Do and DoStream must
+	// never share a client for this: StreamResponseBody is a field on
+	// fasthttp.Client itself, so flipping it per-call would race with
+	// concurrent callers and leave buffered Do calls streaming too.
+	streamClient *fasthttp.Client
+}
+
+// NewFastHTTPTransport builds a FastHTTPTransport with the given timeout
+// and idempotent-call retry count.
+func NewFastHTTPTransport(timeout time.Duration, attempts int) *FastHTTPTransport {
+	return &FastHTTPTransport{
+		Client: &fasthttp.Client{
+			MaxIdemponentCallAttempts: attempts,
+		},
+		streamClient: &fasthttp.Client{
+			MaxIdemponentCallAttempts: attempts,
+			StreamResponseBody:        true,
+		},
+		Timeout: timeout,
+	}
+}
+
+func (t *FastHTTPTransport) Do(ctx context.Context, req *Request) (*Response, error) {
+	return doFastHTTPCtx(ctx, t.Client, t.Timeout, req)
+}
+
+// DoStream behaves like Do but never buffers the response body: on
+// success it leaves fres unreleased and hands it, through
+// releasingBodyStream, to the caller. The caller's Close() is what
+// returns fres to the fasthttp pool, which is why Do cannot simply call
+// this and discard the stream. Like Do, it runs DoTimeout on a background
+// goroutine so ctx cancellation aborts the wait for headers immediately;
+// freq/fres are only released once that goroutine actually returns.
+func (t *FastHTTPTransport) DoStream(ctx context.Context, req *Request) (*Response, error) {
+	freq := fasthttp.AcquireRequest()
+	fres := fasthttp.AcquireResponse()
+
+	fillFastHTTPRequest(freq, req)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- t.streamClient.DoTimeout(freq, fres, t.Timeout)
+	}()
+
+	select {
+	case <-ctx.Done():
+		go func() {
+			<-done
+			fasthttp.ReleaseRequest(freq)
+			fasthttp.ReleaseResponse(fres)
+		}()
+		return nil, ctx.Err()
+	case err := <-done:
+		fasthttp.ReleaseRequest(freq)
+
+		if err != nil {
+			fasthttp.ReleaseResponse(fres)
+			return nil, err
+		}
+
+		return &Response{
+			StatusCode: int32(fres.StatusCode()),
+			Headers:    mergeResponseHeaders(&fres.Header),
+			BodyStream: &releasingBodyStream{reader: fres.BodyStream(), res: fres},
+		}, nil
+	}
+}
+
+// fasthttpDoer is satisfied by both fasthttp.Client and fasthttp.HostClient,
+// letting doFastHTTPCtx back FastHTTPTransport and HostClient alike.
+type fasthttpDoer interface {
+	DoTimeout(req *fasthttp.Request, resp *fasthttp.Response, timeout time.Duration) error
+}
+
+// doFastHTTPCtx runs the request on a background goroutine so that ctx
+// cancellation can abort the caller's wait immediately. freq/fres are only
+// released once that goroutine actually returns, since DoTimeout may
+// still be using them when ctx fires.
+func doFastHTTPCtx(ctx context.Context, client fasthttpDoer, timeout time.Duration, req *Request) (*Response, error) {
+	freq := fasthttp.AcquireRequest()
+	fres := fasthttp.AcquireResponse()
+
+	fillFastHTTPRequest(freq, req)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client.DoTimeout(freq, fres, timeout)
+	}()
+
+	select {
+	case <-ctx.Done():
+		go func() {
+			<-done
+			fasthttp.ReleaseRequest(freq)
+			fasthttp.ReleaseResponse(fres)
+		}()
+		return nil, ctx.Err()
+	case err := <-done:
+		defer fasthttp.ReleaseRequest(freq)
+		defer fasthttp.ReleaseResponse(fres)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return &Response{
+			StatusCode: int32(fres.StatusCode()),
+			Body:       string(fres.Body()),
+			Headers:    mergeResponseHeaders(&fres.Header),
+		}, nil
+	}
+}
+
+func fillFastHTTPRequest(freq *fasthttp.Request, req *Request) {
+	freq.SetRequestURI(req.URL)
+	freq.Header.SetMethod(string(req.Method))
+	freq.Header.SetContentType(req.ContentType)
+
+	if req.BodyReader != nil {
+		// BodyLength <= 0 (including the unset zero value) means unknown:
+		// fasthttp reads the stream until io.EOF instead of sending
+		// Content-Length: 0, which SetBodyStream(r, 0) would otherwise do.
+		length := -1
+		if req.BodyLength > 0 {
+			length = int(req.BodyLength)
+		}
+
+		freq.SetBodyStream(req.BodyReader, length)
+	} else {
+		freq.SetBodyString(req.Body)
+	}
+
+	for k, v := range req.Headers {
+		freq.Header.Set(k, v)
+	}
+}
+
+// releasingBodyStream wraps a fasthttp response body stream so that
+// Close() releases the pooled fasthttp.Response it came from. Callers must
+// always Close() a DoStream result, or the response is never returned to
+// the pool. Close is idempotent: a second call is a no-op, since returning
+// the same *fasthttp.Response to the pool twice could hand it out to two
+// goroutines at once.
+type releasingBodyStream struct {
+	reader io.Reader
+	res    *fasthttp.Response
+	once   sync.Once
+}
+
+func (s *releasingBodyStream) Read(p []byte) (int, error) {
+	return s.reader.Read(p)
+}
+
+func (s *releasingBodyStream) Close() error {
+	s.once.Do(func() {
+		fasthttp.ReleaseResponse(s.res)
+	})
+
+	return nil
+}
+
+// NetHTTPTransport drives requests through net/http.Client, configured for
+// HTTP/2 via golang.org/x/net/http2. Use this when callers need TLS
+// features, trailers, or stdlib-based instrumentation (httptrace,
+// grpc-style interceptors, etc.) that fasthttp does not expose.
+type NetHTTPTransport struct {
+	Client *http.Client
+}
+
+// NewNetHTTPTransport builds a NetHTTPTransport with the given timeout,
+// wiring HTTP/2 support into the underlying http.Transport.
+func NewNetHTTPTransport(timeout time.Duration) (*NetHTTPTransport, error) {
+	rt := &http.Transport{}
+	if err := http2.ConfigureTransport(rt); err != nil {
+		return nil, err
+	}
+
+	return &NetHTTPTransport{
+		Client: &http.Client{
+			Transport: rt,
+			Timeout:   timeout,
+		},
+	}, nil
+}
+
+func (t *NetHTTPTransport) Do(ctx context.Context, req *Request) (*Response, error) {
+	var body io.Reader = strings.NewReader(req.Body)
+	if req.BodyReader != nil {
+		body = req.BodyReader
+	}
+
+	hreq, err := http.NewRequestWithContext(ctx, string(req.Method), req.URL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	hreq.Header.Set("Content-Type", req.ContentType)
+	for k, v := range req.Headers {
+		hreq.Header.Set(k, v)
+	}
+
+	hres, err := t.Client.Do(hreq)
+	if err != nil {
+		return nil, err
+	}
+	defer hres.Body.Close()
+
+	respBody, err := io.ReadAll(hres.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		StatusCode: int32(hres.StatusCode),
+		Body:       string(respBody),
+		Headers:    mergeNetHTTPHeaders(hres.Header),
+	}, nil
+}
+
+// mergeResponseHeaders and mergeNetHTTPHeaders both fold repeated headers
+// (e.g. multiple Set-Cookie lines) into a single comma-joined value per
+// key, per RFC 7230 section 3.2.2, so that FastHTTPTransport and
+// NetHTTPTransport produce identical Response.Headers for the same
+// response. Set-Cookie is exempt from folding under that same section,
+// since cookie values legally contain commas (e.g. Expires dates) and
+// joining them produces unparseable garbage; Response.Headers can only
+// hold one value per key, so for Set-Cookie specifically we keep the
+// last occurrence instead of joining.
+func mergeResponseHeaders(h *fasthttp.ResponseHeader) map[string]string {
+	headers := map[string]string{}
+
+	h.VisitAll(func(key, value []byte) {
+		k := string(key)
+		if isSetCookieHeader(k) {
+			headers[k] = string(value)
+			return
+		}
+
+		if existing, ok := headers[k]; ok {
+			headers[k] = existing + ", " + string(value)
+		} else {
+			headers[k] = string(value)
+		}
+	})
+
+	return headers
+}
+
+func mergeNetHTTPHeaders(h http.Header) map[string]string {
+	headers := map[string]string{}
+
+	for k, v := range h {
+		if isSetCookieHeader(k) {
+			headers[k] = v[len(v)-1]
+			continue
+		}
+
+		headers[k] = strings.Join(v, ", ")
+	}
+
+	return headers
+}
+
+func isSetCookieHeader(key string) bool {
+	return strings.EqualFold(key, "Set-Cookie")
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestReleasingBodyStreamCloseIsIdempotent(t *testing.T) {
+	res := fasthttp.AcquireResponse()
+	res.SetBodyStream(strings.NewReader("payload"), -1)
+
+	s := &releasingBodyStream{reader: res.BodyStream(), res: res}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("first Close() = %v, want nil", err)
+	}
+
+	// A second Close() must not double-release res to the fasthttp pool.
+	if err := s.Close(); err != nil {
+		t.Fatalf("second Close() = %v, want nil", err)
+	}
+}
+
+func TestReleasingBodyStreamRead(t *testing.T) {
+	res := fasthttp.AcquireResponse()
+	res.SetBodyStream(strings.NewReader("payload"), -1)
+
+	s := &releasingBodyStream{reader: res.BodyStream(), res: res}
+	defer s.Close()
+
+	buf := make([]byte, len("payload"))
+	n, err := s.Read(buf)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if string(buf[:n]) != "payload" {
+		t.Errorf("Read() = %q, want %q", buf[:n], "payload")
+	}
+}